@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Formatter renders a set of releases as text in a particular output format.
+type Formatter interface {
+	Format(r Releases, showGA, showBeta, showRC, showSecurity bool, order orderBy) string
+}
+
+// Formatters maps a -format flag value to the Formatter that handles it.
+var Formatters = map[string]Formatter{
+	"csv":      CSVFormatter{},
+	"json":     JSONFormatter{},
+	"markdown": MarkdownFormatter{},
+}
+
+// CSVFormatter renders releases as CSV, one row per version and release type.
+type CSVFormatter struct{}
+
+// Format implements Formatter.
+func (CSVFormatter) Format(r Releases, showGA, showBeta, showRC, showSecurity bool, order orderBy) string {
+	return r.CSV(showGA, showBeta, showRC, showSecurity, order)
+}
+
+// jsonRelease is a single release in the JSON output schema.
+type jsonRelease struct {
+	Version      Version     `json:"version"`
+	Type         ReleaseType `json:"type"`
+	Index        int         `json:"index"`
+	Date         time.Time   `json:"date"`
+	DurationDays int         `json:"duration_days"`
+	Predicted    bool        `json:"predicted"`
+}
+
+// JSONFormatter renders releases as a JSON array of jsonRelease, suitable for
+// feeding dashboards.
+type JSONFormatter struct{}
+
+// Format implements Formatter.
+func (JSONFormatter) Format(r Releases, showGA, showBeta, showRC, showSecurity bool, order orderBy) string {
+	var out []jsonRelease
+	for _, version := range r.orderedVersions(order) {
+		revs := r[version]
+		for _, typ := range releaseTypeOrder {
+			releases := revs[typ]
+			switch {
+			case typ == GARelease && !showGA:
+				continue
+			case typ == BetaRelease && !showBeta:
+				continue
+			case typ == RCRelease && !showRC:
+				continue
+			}
+			for i, release := range releases {
+				out = append(out, jsonRelease{
+					Version:      version,
+					Type:         typ,
+					Index:        i,
+					Date:         release.date,
+					DurationDays: int(release.duration / (86400 * time.Second)),
+					Predicted:    release.predicted,
+				})
+			}
+		}
+	}
+
+	b, err := json.Marshal(out)
+	if err != nil {
+		// out only ever contains types that marshal cleanly.
+		panic(err)
+	}
+	return string(b)
+}
+
+// MarkdownFormatter renders releases as a Markdown table per version, with
+// human-readable durations such as "6w3d".
+type MarkdownFormatter struct{}
+
+// Format implements Formatter.
+func (MarkdownFormatter) Format(r Releases, showGA, showBeta, showRC, showSecurity bool, order orderBy) string {
+	var buf bytes.Buffer
+	for _, version := range r.orderedVersions(order) {
+		revs := r[version]
+		fmt.Fprintf(&buf, "## %v\n\n", version)
+		fmt.Fprintln(&buf, "| Release | Date | Duration |")
+		fmt.Fprintln(&buf, "| --- | --- | --- |")
+		for _, typ := range releaseTypeOrder {
+			releases := revs[typ]
+			switch {
+			case typ == GARelease && !showGA:
+				continue
+			case typ == BetaRelease && !showBeta:
+				continue
+			case typ == RCRelease && !showRC:
+				continue
+			}
+			for i, release := range releases {
+				if release.predicted {
+					fmt.Fprintf(&buf, "| %v%d (predicted) | %v | %v |\n", typ, i, release.date.Format("2006-01-02"), humanDuration(release.duration))
+					continue
+				}
+				fmt.Fprintf(&buf, "| %v%d | %v | %v |\n", typ, i, release.date.Format("2006-01-02"), humanDuration(release.duration))
+			}
+		}
+		fmt.Fprintln(&buf)
+	}
+	return buf.String()
+}
+
+// humanDuration formats d as a short human-readable string, eg "6w3d".
+func humanDuration(d time.Duration) string {
+	days := int(d / (24 * time.Hour))
+	weeks := days / 7
+	days %= 7
+
+	switch {
+	case weeks > 0 && days > 0:
+		return fmt.Sprintf("%dw%dd", weeks, days)
+	case weeks > 0:
+		return fmt.Sprintf("%dw", weeks)
+	default:
+		return fmt.Sprintf("%dd", days)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCSVPredictedRowLabel(t *testing.T) {
+	r := Releases{
+		"1.22": {
+			GARelease: []Release{
+				{date: mustDate(t, "2024-02-06"), duration: 90 * 24 * time.Hour},
+				{date: mustDate(t, "2024-05-06"), predicted: true},
+			},
+		},
+	}
+
+	out := r.CSV(true, false, false, false, orderByVersion)
+	if !strings.Contains(out, "1.22.-predicted,") {
+		t.Errorf("CSV output = %q, want a row labeled 1.22.-predicted", out)
+	}
+}
+
+func TestCSVSecurityRowWithoutShowGA(t *testing.T) {
+	r := Releases{
+		"1.22": {
+			GARelease: []Release{
+				{date: mustDate(t, "2024-02-06")},
+				{date: mustDate(t, "2024-03-06"), security: true},
+			},
+		},
+	}
+
+	out := r.CSV(false, false, false, true, orderByVersion)
+	if !strings.Contains(out, "1.22-sec,") {
+		t.Errorf("CSV output = %q, want a 1.22-sec row even without -show-ga", out)
+	}
+}
+
+func TestJSONFormatterPredictedFlag(t *testing.T) {
+	r := Releases{
+		"1.22": {
+			GARelease: []Release{
+				{date: mustDate(t, "2024-02-06")},
+				{date: mustDate(t, "2024-05-06"), predicted: true},
+			},
+		},
+	}
+
+	out := JSONFormatter{}.Format(r, true, false, false, false, orderByVersion)
+
+	var got []jsonRelease
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("json.Unmarshal(%q): %v", out, err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d releases, want 2", len(got))
+	}
+	if got[0].Predicted {
+		t.Errorf("got[0].Predicted = true, want false")
+	}
+	if !got[1].Predicted {
+		t.Errorf("got[1].Predicted = false, want true")
+	}
+}
+
+func TestMarkdownFormatterPredictedAnnotation(t *testing.T) {
+	r := Releases{
+		"1.22": {
+			GARelease: []Release{
+				{date: mustDate(t, "2024-05-06"), predicted: true},
+			},
+		},
+	}
+
+	out := MarkdownFormatter{}.Format(r, true, false, false, false, orderByVersion)
+	if !strings.Contains(out, "(predicted)") {
+		t.Errorf("Markdown output = %q, want it to annotate the predicted release", out)
+	}
+}
+
+func TestHumanDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0d"},
+		{3 * 24 * time.Hour, "3d"},
+		{7 * 24 * time.Hour, "1w"},
+		{45 * 24 * time.Hour, "6w3d"},
+	}
+	for _, tt := range tests {
+		if got := humanDuration(tt.d); got != tt.want {
+			t.Errorf("humanDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// githubReleasesURL is the API endpoint listing releases of the main Go
+// repo. It's a var, rather than a const, so tests can point it at a fake
+// server.
+var githubReleasesURL = "https://api.github.com/repos/golang/go/releases"
+
+// githubRelease is the subset of the GitHub releases API response we need.
+type githubRelease struct {
+	TagName     string    `json:"tag_name"`
+	PublishedAt time.Time `json:"published_at"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+}
+
+// nextLinkRE extracts the next page URL from a GitHub Link response header,
+// eg `<https://api.github.com/...&page=2>; rel="next", <...>; rel="last"`.
+var nextLinkRE = regexp.MustCompile(`<([^>]+)>; rel="next"`)
+
+// fetchGitHub fetches all releases of golang/go from the GitHub API and
+// returns them in the same tab-separated format MakeReleases parses.
+func fetchGitHub() ([]byte, error) {
+	var buf strings.Builder
+
+	url := githubReleasesURL + "?per_page=100"
+	for url != "" {
+		releases, next, err := fetchGitHubPage(url)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, release := range releases {
+			if release.Draft {
+				continue
+			}
+			if release.Prerelease && !strings.Contains(release.TagName, "beta") && !strings.Contains(release.TagName, "rc") {
+				continue
+			}
+			fmt.Fprintf(&buf, "%v\t%v\n", release.TagName, release.PublishedAt.Format("Mon Jan _2 15:04:05 2006 -0700"))
+		}
+
+		url = next
+	}
+
+	return []byte(buf.String()), nil
+}
+
+// fetchGitHubPage fetches a single page of releases and returns the URL of
+// the next page, if any.
+func fetchGitHubPage(url string) ([]githubRelease, string, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not build request for %v: %v", url, err)
+	}
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "token "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not fetch %v: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("unexpected status fetching %v: %v", url, resp.Status)
+	}
+
+	var releases []githubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, "", fmt.Errorf("could not decode response from %v: %v", url, err)
+	}
+
+	var next string
+	if m := nextLinkRE.FindStringSubmatch(resp.Header.Get("Link")); m != nil {
+		next = m[1]
+	}
+
+	return releases, next, nil
+}
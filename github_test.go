@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFetchGitHubPage(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Authorization"), "token test-token"; got != want {
+			t.Errorf("Authorization header = %q, want %q", got, want)
+		}
+		w.Header().Set("Link", `<https://example.com/releases?page=2>; rel="next", <https://example.com/releases?page=9>; rel="last"`)
+		json.NewEncoder(w).Encode([]githubRelease{
+			{TagName: "go1.21.0", PublishedAt: time.Date(2023, 8, 8, 15, 0, 0, 0, time.UTC)},
+		})
+	}))
+	defer srv.Close()
+
+	releases, next, err := fetchGitHubPage(srv.URL)
+	if err != nil {
+		t.Fatalf("fetchGitHubPage returned error: %v", err)
+	}
+	if want := "https://example.com/releases?page=2"; next != want {
+		t.Errorf("next = %q, want %q", next, want)
+	}
+	if len(releases) != 1 || releases[0].TagName != "go1.21.0" {
+		t.Errorf("releases = %+v, want a single go1.21.0 release", releases)
+	}
+}
+
+func TestFetchGitHubPageNoNextLink(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]githubRelease{})
+	}))
+	defer srv.Close()
+
+	_, next, err := fetchGitHubPage(srv.URL)
+	if err != nil {
+		t.Fatalf("fetchGitHubPage returned error: %v", err)
+	}
+	if next != "" {
+		t.Errorf("next = %q, want empty", next)
+	}
+}
+
+// TestFetchGitHub feeds a representative draft/prerelease/beta/rc/GA mix
+// across two pages through fetchGitHub, then confirms the tab-separated
+// output it produces actually round-trips through MakeReleases.
+func TestFetchGitHub(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "2" {
+			w.Header().Set("Link", `<`+srv.URL+`?per_page=100&page=2>; rel="next"`)
+			json.NewEncoder(w).Encode([]githubRelease{
+				{TagName: "go1.22beta1", PublishedAt: time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC), Prerelease: true},
+				{TagName: "go1.22rc1", PublishedAt: time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC), Prerelease: true},
+				{TagName: "go1.22.0", PublishedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Draft: true},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode([]githubRelease{
+			{TagName: "go1.21.0", PublishedAt: time.Date(2023, 8, 8, 15, 0, 0, 0, time.UTC)},
+			{TagName: "go1.20.0", PublishedAt: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), Prerelease: true},
+		})
+	}))
+	defer srv.Close()
+
+	orig := githubReleasesURL
+	githubReleasesURL = srv.URL
+	defer func() { githubReleasesURL = orig }()
+
+	out, err := fetchGitHub()
+	if err != nil {
+		t.Fatalf("fetchGitHub returned error: %v", err)
+	}
+
+	for _, want := range []string{"go1.22beta1\t", "go1.22rc1\t", "go1.21.0\t"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("fetchGitHub output = %q, want it to contain %q", out, want)
+		}
+	}
+	for _, unwanted := range []string{"go1.22.0\t", "go1.20.0\t"} {
+		if strings.Contains(string(out), unwanted) {
+			t.Errorf("fetchGitHub output = %q, want it to not contain draft/non-beta-rc prerelease %q", out, unwanted)
+		}
+	}
+
+	releases, err := MakeReleases(out, false)
+	if err != nil {
+		t.Fatalf("MakeReleases(fetchGitHub output) returned error: %v", err)
+	}
+	if len(releases["1.22"][BetaRelease]) != 1 {
+		t.Errorf("releases[1.22][BetaRelease] = %+v, want one release", releases["1.22"][BetaRelease])
+	}
+	if len(releases["1.22"][RCRelease]) != 1 {
+		t.Errorf("releases[1.22][RCRelease] = %+v, want one release", releases["1.22"][RCRelease])
+	}
+	if len(releases["1.21"][GARelease]) != 1 {
+		t.Errorf("releases[1.21][GARelease] = %+v, want one release", releases["1.21"][GARelease])
+	}
+}
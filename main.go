@@ -4,33 +4,80 @@ import (
 	"bytes"
 	"flag"
 	"fmt"
-	"io/ioutil"
 	"log"
-	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 )
 
+// releaseTypeOrder fixes the order release types are iterated in, since
+// Releases' inner map would otherwise iterate them non-deterministically.
+var releaseTypeOrder = []ReleaseType{BetaRelease, RCRelease, GARelease}
+
+// orderBy identifies how CSV and the other formatters order rows.
+type orderBy string
+
+const (
+	// orderByVersion orders rows by ascending numeric version.
+	orderByVersion orderBy = "version"
+	// orderByTime orders rows by the date of each version's first release.
+	orderByTime orderBy = "time"
+)
+
 func main() {
 	showGA := flag.Bool("show-ga", false, "Show GA Releases")
 	showBeta := flag.Bool("show-beta", false, "Show Beta Releases")
 	showRC := flag.Bool("show-rc", false, "Show RC Releases")
+	showSecurity := flag.Bool("show-security", false, "Keep superseded patch releases that are known security releases, and show their cadence")
+	source := flag.String("source", "stdin", "Where to read release tags from: stdin, git or github")
+	repo := flag.String("repo", "", "Path to a local go repo checkout, used with -source=git; cloned to a temp dir if empty")
+	format := flag.String("format", "csv", "Output format: csv, json or markdown")
+	orderByFlag := flag.String("order-by", "version", "Order rows by: version or time")
+	predict := flag.Bool("predict", false, "Predict the next release of the most recent version cycle and include it in the output")
 	flag.Parse()
 
-	gittag, err := ioutil.ReadAll(os.Stdin)
+	formatter, ok := Formatters[*format]
+	if !ok {
+		log.Fatalf("unknown format: %v", *format)
+	}
+
+	order := orderBy(*orderByFlag)
+	if order != orderByVersion && order != orderByTime {
+		log.Fatalf("unknown order-by: %v", *orderByFlag)
+	}
+
+	gittag, err := Fetch(Source(*source), *repo)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	releases, err := MakeReleases(gittag)
+	releases, err := MakeReleases(gittag, *showSecurity)
 	if err != nil {
 		log.Fatal(err)
 	}
 	releases.SetDurations()
 
-	fmt.Print(releases.CSV(*showGA, *showBeta, *showRC))
+	if *predict {
+		versions := releases.sortedVersions()
+		if len(versions) == 0 {
+			log.Fatal("no releases to predict from")
+		}
+		version := versions[len(versions)-1]
+
+		typ, num := releases.nextSlot(version)
+		date, ci := releases.Predict(version, typ, num)
+		if date.IsZero() {
+			log.Fatalf("not enough historical data to predict %v%v%d", version, typ, num)
+		}
+
+		log.Printf("predicted %v%v%d: %v (±%v)", version, typ, num, date.Format("2006-01-02"), ci.Upper.Sub(date))
+		releases.AddPredicted(version, typ, num, date)
+		releases.SetDurations()
+	}
+
+	fmt.Print(formatter.Format(releases, *showGA, *showBeta, *showRC, *showSecurity, order))
 }
 
 type (
@@ -51,8 +98,10 @@ const (
 
 // A Release is the time when a release occurred.
 type Release struct {
-	date     time.Time
-	duration time.Duration
+	date      time.Time
+	duration  time.Duration
+	security  bool
+	predicted bool
 }
 
 // Releases holds all the releases for all versions for all release types.
@@ -74,7 +123,7 @@ type Releases map[Version]map[ReleaseType][]Release
 //
 // This output can be obtained with: git tag --format '%(refname),%(authordate)' --sort=authordate
 //
-func MakeReleases(out []byte) (Releases, error) {
+func MakeReleases(out []byte, showSecurity bool) (Releases, error) {
 	// sample: go1.7rc1   Thu Jul 7 16:41:29 2016 -0700
 	// go versions: go1.8 or go1.8beta1 or go1.9rc1 or go1.8.1
 	tags := regexp.MustCompile(`go([0-9]+\.[0-9]+)(\.|rc|beta|)([0-9+]|)\t(.*)`+"\n").FindAllStringSubmatch(string(out), -1)
@@ -104,18 +153,24 @@ func MakeReleases(out []byte) (Releases, error) {
 			revType = GARelease
 		}
 
-		releases.Add(version, revType, int(num), date)
+		releases.Add(version, revType, int(num), date, showSecurity)
 	}
 	return releases, nil
 }
 
 // Add adds a version, type, number that occurred on date to the releases.
-func (r Releases) Add(version Version, typ ReleaseType, num int, date time.Time) {
+// When showSecurity is true, superseded patch releases are kept if they're
+// known security releases, instead of being discarded as usual.
+func (r Releases) Add(version Version, typ ReleaseType, num int, date time.Time, showSecurity bool) {
 	if _, ok := r[version]; !ok {
 		r[version] = make(map[ReleaseType][]Release)
 	}
 
-	if _, ok := r[nextVersion(version)][GARelease]; ok && typ == GARelease {
+	// Security releases are only ever GA patches; beta/rc numbering reuses
+	// the same patch numbers, so this must not be checked for those types.
+	security := typ == GARelease && isSecurityRelease(version, num)
+
+	if _, ok := r[nextVersion(version)][GARelease]; ok && typ == GARelease && !(showSecurity && security) {
 		// Ignore old GA releases when a newer GA is available, eg, if 1.6
 		// has come out and 1.5.4 is also released, ignore the 1.5.4. It's
 		// usually just small security patches, and this makes time simple
@@ -123,7 +178,7 @@ func (r Releases) Add(version Version, typ ReleaseType, num int, date time.Time)
 		return
 	}
 
-	r[version][typ] = append(r[version][typ], Release{date: date})
+	r[version][typ] = append(r[version][typ], Release{date: date, security: security})
 }
 
 // SetDurations sets the durations on each release based on when the next
@@ -153,11 +208,12 @@ func (r Releases) SetDurations() {
 
 	// Set releases that don't have a duration to end today. This allows a user
 	// to see where the current release is in comparion to previous releases.
-	// This should only affect the latest/current beta or rc and ga.
+	// This should only affect the latest/current beta or rc and ga. Predicted
+	// releases are left alone; they have no "now" to measure against.
 	for version, revs := range r {
 		for typ, releases := range revs {
 			for i, release := range releases {
-				if release.duration == 0 {
+				if release.duration == 0 && !release.predicted {
 					r.SetDuration(version, typ, time.Now(), i)
 				}
 			}
@@ -181,14 +237,21 @@ func (r Releases) SetDuration(version Version, typ ReleaseType, date time.Time,
 	r[version][typ][idx].duration = d
 }
 
-// CSV returns a CSV of the releases.
-func (r Releases) CSV(showGA, showBeta, showRC bool) string {
+// CSV returns a CSV of the releases, with rows ordered by order. When
+// showSecurity is true, an extra "<version>-sec" row is emitted per version
+// regardless of showGA, reporting the time between that version's security
+// releases instead of between all releases. A row ending in a predicted
+// release has its label suffixed with "-predicted", since its last value is
+// a forecast rather than an observed release.
+func (r Releases) CSV(showGA, showBeta, showRC, showSecurity bool, order orderBy) string {
 	var (
 		buf    bytes.Buffer
 		header = []string{""}
 	)
-	for version, revs := range r {
-		for typ, releases := range revs {
+	for _, version := range r.orderedVersions(order) {
+		revs := r[version]
+		for _, typ := range releaseTypeOrder {
+			releases := revs[typ]
 			switch {
 			case typ == GARelease && !showGA:
 				continue
@@ -197,7 +260,11 @@ func (r Releases) CSV(showGA, showBeta, showRC bool) string {
 			case typ == RCRelease && !showRC:
 				continue
 			}
-			fmt.Fprintf(&buf, "%v%v,", version, typ)
+			label := fmt.Sprintf("%v%v", version, typ)
+			if len(releases) > 0 && releases[len(releases)-1].predicted {
+				label += "-predicted"
+			}
+			fmt.Fprintf(&buf, "%v,", label)
 			for i, release := range releases {
 				if i > len(header)-2 {
 					header = append(header, fmt.Sprintf("%d", i))
@@ -206,10 +273,87 @@ func (r Releases) CSV(showGA, showBeta, showRC bool) string {
 			}
 			fmt.Fprintln(&buf)
 		}
+
+		if showSecurity {
+			fmt.Fprintf(&buf, "%v-sec,", version)
+			for i, duration := range r.SecurityDurations(version) {
+				if i > len(header)-2 {
+					header = append(header, fmt.Sprintf("%d", i))
+				}
+				fmt.Fprintf(&buf, "%d,", duration/(86400*time.Second))
+			}
+			fmt.Fprintln(&buf)
+		}
 	}
 	return fmt.Sprintf("%s\n%s", strings.Join(header, ","), buf.String())
 }
 
+// sortedVersions returns r's versions ordered numerically ascending by major
+// then minor, unlike Go's randomised map iteration order.
+func (r Releases) sortedVersions() []Version {
+	versions := make([]Version, 0, len(r))
+	for version := range r {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		iMajor, iMinor := parseVersion(versions[i])
+		jMajor, jMinor := parseVersion(versions[j])
+		if iMajor != jMajor {
+			return iMajor < jMajor
+		}
+		return iMinor < jMinor
+	})
+	return versions
+}
+
+// orderedVersions returns r's versions ordered according to order: ascending
+// numeric version, or ascending date of each version's first release.
+func (r Releases) orderedVersions(order orderBy) []Version {
+	versions := r.sortedVersions()
+	if order != orderByTime {
+		return versions
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		return r.firstReleaseDate(versions[i]).Before(r.firstReleaseDate(versions[j]))
+	})
+	return versions
+}
+
+// firstReleaseDate returns the date of version's first release: its first
+// beta, or its first GA if it never had a beta.
+func (r Releases) firstReleaseDate(version Version) time.Time {
+	if betas := r[version][BetaRelease]; len(betas) > 0 {
+		return betas[0].date
+	}
+	if gas := r[version][GARelease]; len(gas) > 0 {
+		return gas[0].date
+	}
+	return time.Time{}
+}
+
+// SecurityDurations returns the time between consecutive security releases
+// of version, starting from its initial GA release.
+func (r Releases) SecurityDurations(version Version) []time.Duration {
+	ga := r[version][GARelease]
+	if len(ga) == 0 {
+		return nil
+	}
+
+	var (
+		durations []time.Duration
+		last      = ga[0].date
+	)
+	for _, release := range ga[1:] {
+		if !release.security {
+			continue
+		}
+		durations = append(durations, release.date.Sub(last))
+		last = release.date
+	}
+	return durations
+}
+
 func nextVersion(current Version) (next Version) {
 	major, minor := parseVersion(current)
 	return Version(fmt.Sprintf("%d.%d", major, minor+1))
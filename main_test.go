@@ -0,0 +1,61 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		version    Version
+		major, min int
+	}{
+		{"1.2", 1, 2},
+		{"1.10", 1, 10},
+		{"2.0", 2, 0},
+	}
+	for _, tt := range tests {
+		major, minor := parseVersion(tt.version)
+		if major != tt.major || minor != tt.min {
+			t.Errorf("parseVersion(%v) = %v, %v, want %v, %v", tt.version, major, minor, tt.major, tt.min)
+		}
+	}
+}
+
+func TestSortedVersions(t *testing.T) {
+	r := Releases{
+		"1.10": nil,
+		"1.2":  nil,
+		"1.9":  nil,
+		"2.0":  nil,
+	}
+
+	got := r.sortedVersions()
+	want := []Version{"1.2", "1.9", "1.10", "2.0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sortedVersions() = %v, want %v", got, want)
+	}
+}
+
+func TestOrderedVersionsByTime(t *testing.T) {
+	r := Releases{
+		"1.10": {GARelease: []Release{{date: mustDate(t, "2020-06-01")}}},
+		"1.9":  {GARelease: []Release{{date: mustDate(t, "2020-01-01")}}},
+	}
+
+	got := r.orderedVersions(orderByTime)
+	want := []Version{"1.9", "1.10"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("orderedVersions(orderByTime) = %v, want %v", got, want)
+	}
+}
+
+func mustDate(t *testing.T, s string) time.Time {
+	t.Helper()
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		t.Fatalf("time.Parse(%q): %v", s, err)
+	}
+	return d
+}
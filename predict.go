@@ -0,0 +1,206 @@
+package main
+
+import (
+	"math"
+	"time"
+)
+
+// ConfidenceInterval is a ±1 standard deviation window around a predicted
+// time.
+type ConfidenceInterval struct {
+	Lower time.Time
+	Upper time.Time
+}
+
+// Predict forecasts when version's in-flight typ num release (eg beta2, rc1,
+// .1) is likely to occur. It collects, from every other version, the
+// historical duration between the prior sibling release and that same slot,
+// then projects their mean ± sample standard deviation (meanDuration,
+// stddevDuration) from the most recently observed release in
+// version's own cycle.
+//
+// It returns the zero Time and a zero ConfidenceInterval if version has no
+// observed release to project from, or if no other version ever reached
+// that slot.
+func (r Releases) Predict(version Version, typ ReleaseType, num int) (time.Time, ConfidenceInterval) {
+	anchor, ok := r.anchorDate(version, typ, num)
+	if !ok {
+		return time.Time{}, ConfidenceInterval{}
+	}
+
+	var durations []time.Duration
+	for _, v := range r.sortedVersions() {
+		if v == version {
+			continue
+		}
+		if d, ok := r.transitionDuration(v, typ, num); ok {
+			durations = append(durations, d)
+		}
+	}
+	if len(durations) == 0 {
+		return time.Time{}, ConfidenceInterval{}
+	}
+
+	mean := meanDuration(durations)
+	stddev := stddevDuration(durations, mean)
+	predicted := anchor.Add(mean)
+
+	return predicted, ConfidenceInterval{
+		Lower: predicted.Add(-stddev),
+		Upper: predicted.Add(stddev),
+	}
+}
+
+// slot identifies a release by type and number within a version's cycle.
+type slot struct {
+	typ ReleaseType
+	num int
+}
+
+// nextSlot returns the next release slot expected for version that hasn't
+// happened yet. It prefers another release of the current phase (eg beta3
+// after beta1, beta2), but falls back to the first release of the next
+// phase (eg rc1) when no other version's history has ever reached another
+// release of the current phase — the common case, since most versions move
+// from their last beta or rc straight into the next phase rather than
+// growing another one.
+func (r Releases) nextSlot(version Version) (ReleaseType, int) {
+	candidates := r.slotCandidates(version)
+	for _, c := range candidates {
+		if r.hasHistory(c.typ, c.num) {
+			return c.typ, c.num
+		}
+	}
+	return candidates[0].typ, candidates[0].num
+}
+
+// slotCandidates returns version's possible next slots, most likely first.
+func (r Releases) slotCandidates(version Version) []slot {
+	switch {
+	case len(r[version][GARelease]) > 0:
+		return []slot{{GARelease, len(r[version][GARelease])}}
+	case len(r[version][RCRelease]) > 0:
+		return []slot{
+			{RCRelease, len(r[version][RCRelease]) + 1},
+			{GARelease, 0},
+		}
+	case len(r[version][BetaRelease]) > 0:
+		return []slot{
+			{BetaRelease, len(r[version][BetaRelease]) + 1},
+			{RCRelease, 1},
+		}
+	default:
+		return []slot{{BetaRelease, 1}}
+	}
+}
+
+// hasHistory reports whether any version has ever reached slot (typ, num),
+// ie whether Predict has data to project from.
+func (r Releases) hasHistory(typ ReleaseType, num int) bool {
+	for _, v := range r.sortedVersions() {
+		if _, ok := r.transitionDuration(v, typ, num); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// AddPredicted inserts a synthetic, predicted release into version's typ
+// slot at date, so it's rendered alongside real releases by the output
+// formatters.
+func (r Releases) AddPredicted(version Version, typ ReleaseType, num int, date time.Time) {
+	if _, ok := r[version]; !ok {
+		r[version] = make(map[ReleaseType][]Release)
+	}
+	r[version][typ] = append(r[version][typ], Release{date: date, predicted: true})
+}
+
+// anchorDate returns the date of the release immediately preceding
+// version's typ num release, ie the most recently observed release in its
+// cycle to project the prediction from.
+func (r Releases) anchorDate(version Version, typ ReleaseType, num int) (time.Time, bool) {
+	pred, ok := r.predecessor(version, typ, num)
+	if !ok {
+		return time.Time{}, false
+	}
+	return pred.date, true
+}
+
+// transitionDuration returns the historical duration between the release
+// preceding version's typ num release and that release itself, if version
+// actually reached that slot.
+func (r Releases) transitionDuration(version Version, typ ReleaseType, num int) (time.Duration, bool) {
+	if !r.slotExists(version, typ, num) {
+		return 0, false
+	}
+	pred, ok := r.predecessor(version, typ, num)
+	if !ok {
+		return 0, false
+	}
+	return pred.duration, true
+}
+
+// slotExists reports whether version's typ num release has already
+// occurred.
+func (r Releases) slotExists(version Version, typ ReleaseType, num int) bool {
+	if typ == GARelease {
+		return num < len(r[version][GARelease])
+	}
+	return num > 0 && num-1 < len(r[version][typ])
+}
+
+// predecessor returns the release that occurs immediately before version's
+// typ num release in its release cycle: the prior beta/rc/patch, or the
+// last release of the previous stage for beta1, rc1 and .0.
+func (r Releases) predecessor(version Version, typ ReleaseType, num int) (Release, bool) {
+	switch {
+	case typ == BetaRelease && num > 1:
+		return nthRelease(r[version][BetaRelease], num-2)
+	case typ == RCRelease && num == 1:
+		betas := r[version][BetaRelease]
+		return nthRelease(betas, len(betas)-1)
+	case typ == RCRelease && num > 1:
+		return nthRelease(r[version][RCRelease], num-2)
+	case typ == GARelease && num == 0:
+		rcs := r[version][RCRelease]
+		return nthRelease(rcs, len(rcs)-1)
+	case typ == GARelease && num > 0:
+		return nthRelease(r[version][GARelease], num-1)
+	default:
+		// beta1 has no predecessor; it's the start of a new cycle.
+		return Release{}, false
+	}
+}
+
+// nthRelease returns releases[idx], or false if idx is out of range.
+func nthRelease(releases []Release, idx int) (Release, bool) {
+	if idx < 0 || idx >= len(releases) {
+		return Release{}, false
+	}
+	return releases[idx], true
+}
+
+// meanDuration returns the arithmetic mean of durations.
+func meanDuration(durations []time.Duration) time.Duration {
+	var sum time.Duration
+	for _, d := range durations {
+		sum += d
+	}
+	return sum / time.Duration(len(durations))
+}
+
+// stddevDuration returns the sample standard deviation of durations around
+// mean. It returns 0 if fewer than two durations are given.
+func stddevDuration(durations []time.Duration, mean time.Duration) time.Duration {
+	if len(durations) < 2 {
+		return 0
+	}
+
+	var sumSquares float64
+	for _, d := range durations {
+		diff := float64(d - mean)
+		sumSquares += diff * diff
+	}
+	variance := sumSquares / float64(len(durations)-1)
+	return time.Duration(math.Sqrt(variance))
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// TestNextSlotFallsBackToNextPhase reproduces the common case where an
+// in-flight version has the same number of betas as a historical version
+// that went on to rc1: nextSlot must predict the next phase (rc1), not
+// another beta that no version's history ever reaches.
+func TestNextSlotFallsBackToNextPhase(t *testing.T) {
+	r := Releases{
+		// Historical version: beta1, beta2, rc1.
+		"1.20": {
+			BetaRelease: []Release{
+				{date: mustDate(t, "2020-01-01")},
+				{date: mustDate(t, "2020-01-15"), duration: 10 * 24 * time.Hour},
+			},
+			RCRelease: []Release{
+				{date: mustDate(t, "2020-01-25")},
+			},
+		},
+		// In-flight version: only beta1, beta2 so far.
+		"1.21": {
+			BetaRelease: []Release{
+				{date: mustDate(t, "2021-01-01")},
+				{date: mustDate(t, "2021-01-15")},
+			},
+		},
+	}
+
+	typ, num := r.nextSlot("1.21")
+	if typ != RCRelease || num != 1 {
+		t.Errorf("nextSlot(1.21) = %v%d, want rc1", typ, num)
+	}
+}
+
+// TestNextSlotNoFallbackWhenHistorySupportsIt confirms nextSlot still
+// predicts another beta when some version's history actually reached it.
+func TestNextSlotNoFallbackWhenHistorySupportsIt(t *testing.T) {
+	r := Releases{
+		"1.20": {
+			BetaRelease: []Release{
+				{date: mustDate(t, "2020-01-01")},
+				{date: mustDate(t, "2020-01-15"), duration: 10 * 24 * time.Hour},
+				{date: mustDate(t, "2020-01-25")},
+			},
+		},
+		"1.21": {
+			BetaRelease: []Release{
+				{date: mustDate(t, "2021-01-01")},
+				{date: mustDate(t, "2021-01-15")},
+			},
+		},
+	}
+
+	typ, num := r.nextSlot("1.21")
+	if typ != BetaRelease || num != 3 {
+		t.Errorf("nextSlot(1.21) = %v%d, want beta3", typ, num)
+	}
+}
+
+// TestPredict exercises the mean/stddev projection against a small
+// synthetic fixture with a known answer: two historical versions transition
+// from their last beta into rc1 after 10 and 14 days respectively, so the
+// mean is 12 days and the sample standard deviation is sqrt(8) days.
+func TestPredict(t *testing.T) {
+	anchor := mustDate(t, "2022-01-15")
+	r := Releases{
+		"1.20": {
+			BetaRelease: []Release{
+				{date: mustDate(t, "2020-01-15"), duration: 10 * 24 * time.Hour},
+			},
+			RCRelease: []Release{
+				{date: mustDate(t, "2020-01-25")},
+			},
+		},
+		"1.21": {
+			BetaRelease: []Release{
+				{date: mustDate(t, "2021-01-15"), duration: 14 * 24 * time.Hour},
+			},
+			RCRelease: []Release{
+				{date: mustDate(t, "2021-01-29")},
+			},
+		},
+		"1.22": {
+			BetaRelease: []Release{
+				{date: anchor},
+			},
+		},
+	}
+
+	wantMean := meanDuration([]time.Duration{10 * 24 * time.Hour, 14 * 24 * time.Hour})
+	wantStdDev := stddevDuration([]time.Duration{10 * 24 * time.Hour, 14 * 24 * time.Hour}, wantMean)
+	wantDate := anchor.Add(wantMean)
+
+	gotDate, ci := r.Predict("1.22", RCRelease, 1)
+	if !gotDate.Equal(wantDate) {
+		t.Errorf("Predict(1.22, rc, 1) date = %v, want %v", gotDate, wantDate)
+	}
+	if !ci.Lower.Equal(wantDate.Add(-wantStdDev)) {
+		t.Errorf("Predict(1.22, rc, 1) lower bound = %v, want %v", ci.Lower, wantDate.Add(-wantStdDev))
+	}
+	if !ci.Upper.Equal(wantDate.Add(wantStdDev)) {
+		t.Errorf("Predict(1.22, rc, 1) upper bound = %v, want %v", ci.Upper, wantDate.Add(wantStdDev))
+	}
+}
+
+// TestPredictNoHistory confirms Predict reports failure, rather than
+// guessing, when no other version ever reached the requested slot.
+func TestPredictNoHistory(t *testing.T) {
+	r := Releases{
+		"1.22": {
+			BetaRelease: []Release{
+				{date: mustDate(t, "2022-01-15")},
+			},
+		},
+	}
+
+	date, ci := r.Predict("1.22", RCRelease, 1)
+	if !date.IsZero() {
+		t.Errorf("Predict(1.22, rc, 1) date = %v, want zero", date)
+	}
+	if ci != (ConfidenceInterval{}) {
+		t.Errorf("Predict(1.22, rc, 1) confidence interval = %+v, want zero value", ci)
+	}
+}
+
+func TestMeanAndStdDevDuration(t *testing.T) {
+	durations := []time.Duration{10 * 24 * time.Hour, 14 * 24 * time.Hour}
+
+	mean := meanDuration(durations)
+	if want := 12 * 24 * time.Hour; mean != want {
+		t.Errorf("meanDuration(%v) = %v, want %v", durations, mean, want)
+	}
+
+	stddev := stddevDuration(durations, mean)
+	stddevDays := 2.8284271247461903 // sqrt(8)
+	want := time.Duration(stddevDays * float64(24*time.Hour))
+	if diff := stddev - want; diff > time.Millisecond || diff < -time.Millisecond {
+		t.Errorf("stddevDuration(%v, %v) = %v, want ~%v", durations, mean, stddev, want)
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+// securityRelease identifies a single patch release by its minor version and
+// patch number, eg Version "1.5", Patch 4 for "go1.5.4".
+type securityRelease struct {
+	Version Version
+	Patch   int
+}
+
+// securityReleases is a hand-maintained table of patch releases known to
+// primarily address a security vulnerability, mirroring the structure of the
+// release history maintained on the Go website
+// (https://go.dev/doc/devel/release). It is not exhaustive; add an entry
+// whenever a new security release ships.
+var securityReleases = map[securityRelease]bool{
+	{Version: "1.5", Patch: 4}:   true,
+	{Version: "1.16", Patch: 8}:  true,
+	{Version: "1.17", Patch: 2}:  true,
+	{Version: "1.19", Patch: 11}: true,
+	{Version: "1.20", Patch: 6}:  true,
+	{Version: "1.21", Patch: 1}:  true,
+}
+
+// isSecurityRelease reports whether version's patch release num is a known
+// security release.
+func isSecurityRelease(version Version, num int) bool {
+	return securityReleases[securityRelease{Version: version, Patch: num}]
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+)
+
+// Source identifies where release tag data is read from.
+type Source string
+
+const (
+	// SourceStdin reads `git tag --format ...` output piped in on stdin.
+	SourceStdin Source = "stdin"
+	// SourceGit shells out to git against a local or freshly cloned checkout.
+	SourceGit Source = "git"
+	// SourceGitHub fetches releases from the GitHub API.
+	SourceGitHub Source = "github"
+)
+
+// goGitURL is the canonical upstream repo cloned when -repo isn't provided.
+const goGitURL = "https://go.googlesource.com/go"
+
+// Fetch reads raw `git tag --format ...`-style tab-separated output from the
+// given source. For SourceGit, repo is the path to a local checkout; if
+// empty, goGitURL is cloned into a temporary directory first.
+func Fetch(source Source, repo string) ([]byte, error) {
+	switch source {
+	case SourceStdin:
+		return ioutil.ReadAll(os.Stdin)
+	case SourceGit:
+		return fetchGit(repo)
+	case SourceGitHub:
+		return fetchGitHub()
+	default:
+		return nil, fmt.Errorf("unknown source: %q", source)
+	}
+}
+
+// fetchGit runs `git tag --format ... --sort=authordate` against repo and
+// returns its output in the same format MakeReleases parses. If repo is
+// empty, goGitURL is cloned into a temporary directory first.
+func fetchGit(repo string) ([]byte, error) {
+	if repo == "" {
+		dir, err := ioutil.TempDir("", "go-release-cycle")
+		if err != nil {
+			return nil, fmt.Errorf("could not create temp dir: %v", err)
+		}
+		defer os.RemoveAll(dir)
+
+		cmd := exec.Command("git", "clone", "--bare", goGitURL, dir)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return nil, fmt.Errorf("could not clone %v: %v: %s", goGitURL, err, out)
+		}
+		repo = dir
+	}
+
+	cmd := exec.Command("git", "-C", repo, "tag", "--format", "%(refname)\t%(authordate)", "--sort=authordate")
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("could not list tags in %v: %v", repo, err)
+	}
+	return out, nil
+}
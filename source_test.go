@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// TestFetchGit exercises the -source=git path against a real local repo,
+// then confirms the tag format it produces round-trips through
+// MakeReleases.
+func TestFetchGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+
+	run("init")
+	run("config", "user.email", "test@example.com")
+	run("config", "user.name", "test")
+	run("commit", "--allow-empty", "-m", "initial commit")
+	run("tag", "go1.21.0")
+	run("commit", "--allow-empty", "-m", "next commit")
+	run("tag", "go1.22beta1")
+
+	out, err := fetchGit(dir)
+	if err != nil {
+		t.Fatalf("fetchGit returned error: %v", err)
+	}
+
+	for _, want := range []string{"go1.21.0\t", "go1.22beta1\t"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("fetchGit output = %q, want it to contain %q", out, want)
+		}
+	}
+
+	releases, err := MakeReleases(out, false)
+	if err != nil {
+		t.Fatalf("MakeReleases(fetchGit output) returned error: %v", err)
+	}
+	if len(releases["1.21"][GARelease]) != 1 {
+		t.Errorf("releases[1.21][GARelease] = %+v, want one release", releases["1.21"][GARelease])
+	}
+	if len(releases["1.22"][BetaRelease]) != 1 {
+		t.Errorf("releases[1.22][BetaRelease] = %+v, want one release", releases["1.22"][BetaRelease])
+	}
+}